@@ -1,12 +1,14 @@
 package dict
 
 import (
+	"fmt"
 	"hash/maphash"
 	"unsafe"
 
 	"github.com/kulics/gollection/option"
 	"github.com/kulics/gollection/ref"
 	"github.com/kulics/gollection/seq"
+	"golang.org/x/exp/constraints"
 )
 
 const defaultElementsLength = 10
@@ -172,6 +174,14 @@ func (a *Dict[K, V]) Add(key K, value V) option.Option[V] {
 	return option.None[V]()
 }
 
+// Add the key and value, and panic if the key already exists instead of overwriting it.
+func (a *Dict[K, V]) PutStrict(key K, value V) {
+	if a.Contains(key) {
+		panic(fmt.Sprintf("duplicate key: %v", key))
+	}
+	a.Add(key, value)
+}
+
 func (a *Dict[K, V]) Remove(key K) option.Option[V] {
 	var hash = a.hash(key)
 	var index = a.index(hash)
@@ -195,13 +205,28 @@ func (a *Dict[K, V]) Remove(key K) option.Option[V] {
 			}
 			a.entries[i] = empty
 			a.freeCount = i
-			a.freeCount++
+			a.freeLength++
 			return option.Some(item.value)
 		}
+		last = i
 	}
 	return option.None[V]()
 }
 
+// Remove all entries whose key matches the predicate, and return the removed keys.
+func (a *Dict[K, V]) InvalidateMatching(predicate func(K) bool) []K {
+	var keys = make([]K, 0)
+	for i := 0; i < len(a.entries); i++ {
+		if a.entries[i].alive && predicate(a.entries[i].key) {
+			keys = append(keys, a.entries[i].key)
+		}
+	}
+	for _, k := range keys {
+		a.Remove(k)
+	}
+	return keys
+}
+
 func (a *Dict[K, V]) Clear() {
 	for i := 0; i < len(a.buckets); i++ {
 		a.buckets[i] = -1
@@ -215,6 +240,19 @@ func (a *Dict[K, V]) Iterator() seq.Iterator[Entry[K, V]] {
 	return &hashDictIterator[K, V]{-1, a}
 }
 
+// The action is executed for each entry of the Dict, the same Entry buffer is refilled and reused for every call,
+// so the pointer must not be retained after the action returns.
+func (a *Dict[K, V]) ForEachPair(action func(*Entry[K, V])) {
+	var buffer Entry[K, V]
+	for i := 0; i < len(a.entries); i++ {
+		if a.entries[i].alive {
+			buffer.Key = a.entries[i].key
+			buffer.Value = a.entries[i].value
+			action(&buffer)
+		}
+	}
+}
+
 func (a *Dict[K, V]) Clone() *Dict[K, V] {
 	var buckets = make([]int, len(a.buckets))
 	copy(buckets, a.buckets)
@@ -231,6 +269,54 @@ func (a *Dict[K, V]) Clone() *Dict[K, V] {
 	}
 }
 
+// Returns false if the longest bucket chain exceeds maxChainFactor times the average chain length
+// (at least 1), which usually indicates a poor hasher.
+func (a *Dict[K, V]) IsWellBalanced(maxChainFactor float64) bool {
+	var count = a.Count()
+	if count == 0 {
+		return true
+	}
+	var longest = 0
+	for _, head := range a.buckets {
+		var length = 0
+		for i := head; i >= 0; i = a.entries[i].next {
+			length++
+		}
+		if length > longest {
+			longest = length
+		}
+	}
+	// A chain can not be shorter than 1, so sparse dicts are compared against 1 instead of a tiny average.
+	var average = float64(count) / float64(len(a.buckets))
+	if average < 1 {
+		average = 1
+	}
+	return float64(longest) <= maxChainFactor*average
+}
+
+// Clone the Dict and every value via cloneValue, so that reference-type values are not shared.
+func (a *Dict[K, V]) DeepClone(cloneValue func(V) V) *Dict[K, V] {
+	var dict = a.Clone()
+	for i := 0; i < len(dict.entries); i++ {
+		if dict.entries[i].alive {
+			dict.entries[i].value = cloneValue(dict.entries[i].value)
+		}
+	}
+	return dict
+}
+
+// The visit is executed for each bucket of the Dict, including empty ones,
+// and receives a copy of the entries chained in that bucket.
+func (a *Dict[K, V]) ForEachBucket(visit func(bucketIndex int, entries []Entry[K, V])) {
+	for index, head := range a.buckets {
+		var entries = make([]Entry[K, V], 0)
+		for i := head; i >= 0; i = a.entries[i].next {
+			entries = append(entries, Entry[K, V]{a.entries[i].key, a.entries[i].value})
+		}
+		visit(index, entries)
+	}
+}
+
 func (a *Dict[K, V]) grow(minCapacity int) bool {
 	var entriesLength = len(a.entries)
 	var bucketsLength = len(a.buckets)
@@ -284,6 +370,19 @@ func (a *hashDictIterator[K, V]) Next() option.Option[Entry[K, V]] {
 	return option.None[Entry[K, V]]()
 }
 
+// Returns a new Dict with only the entries whose value is of type V, the hasher is reused.
+func CollectByType[K comparable, V any](a *Dict[K, any]) *Dict[K, V] {
+	var dict = MakeWithHasher[K, V](a.hash, 0)
+	for i := 0; i < len(a.entries); i++ {
+		if a.entries[i].alive {
+			if v, ok := a.entries[i].value.(V); ok {
+				dict.Add(a.entries[i].key, v)
+			}
+		}
+	}
+	return dict
+}
+
 func Collector[K comparable, V any]() seq.Collector[*Dict[K, V], Entry[K, V], *Dict[K, V]] {
 	return collector[K, V]{}
 }
@@ -318,3 +417,30 @@ func Equals[K comparable, V comparable](l Dict[K, V], r Dict[K, V]) bool {
 	}
 	return true
 }
+
+// Return the maximum key of the Dict, or None if it is empty.
+func MaxKey[K constraints.Integer | constraints.Float, V any](a *Dict[K, V]) option.Option[K] {
+	return seq.Max[K](seq.Map[Entry[K, V], K](func(e Entry[K, V]) K {
+		return e.Key
+	}, a))
+}
+
+// Return the minimum key of the Dict, or None if it is empty.
+func MinKey[K constraints.Integer | constraints.Float, V any](a *Dict[K, V]) option.Option[K] {
+	return seq.Min[K](seq.Map[Entry[K, V], K](func(e Entry[K, V]) K {
+		return e.Key
+	}, a))
+}
+
+// Returns true if both Dicts have exactly the same set of keys, values are ignored.
+func EqualKeys[K comparable, V any, W any](l *Dict[K, V], r *Dict[K, W]) bool {
+	if l.Count() != r.Count() {
+		return false
+	}
+	for i := 0; i < len(l.entries); i++ {
+		if l.entries[i].alive && !r.Contains(l.entries[i].key) {
+			return false
+		}
+	}
+	return true
+}
@@ -49,3 +49,319 @@ func TestHashDict(t *testing.T) {
 		t.Fatal("dict value not eq 2")
 	}
 }
+
+func TestRemove(t *testing.T) {
+	var dict = MakeWithHasher[int, int](func(k int) uint64 {
+		return 0
+	}, 0)
+	for i := 1; i <= 5; i++ {
+		dict.Add(i, i*10)
+	}
+	// The chain is 5 -> 4 -> 3 -> 2 -> 1, so 3 is in the middle and 1 is the tail.
+	if v, ok := dict.Remove(3).Val(); !ok || v != 30 {
+		t.Fatal("removed value not eq 30")
+	}
+	if v, ok := dict.Remove(1).Val(); !ok || v != 10 {
+		t.Fatal("removed value not eq 10")
+	}
+	if dict.Remove(3).IsSome() {
+		t.Fatal("removed key removed again")
+	}
+	if dict.Count() != 3 {
+		t.Fatal("dict count not eq 3")
+	}
+	for _, k := range []int{2, 4, 5} {
+		if v, ok := dict.At(k).Val(); !ok || v != k*10 {
+			t.Fatal("remaining entry lost")
+		}
+	}
+	if dict.Contains(1) || dict.Contains(3) {
+		t.Fatal("removed entry still exists")
+	}
+	var appendCount = dict.appendCount
+	dict.Add(6, 60)
+	dict.Add(7, 70)
+	if dict.appendCount != appendCount {
+		t.Fatal("free slots not reused")
+	}
+	if dict.Count() != 5 {
+		t.Fatal("dict count not eq 5")
+	}
+	for _, k := range []int{2, 4, 5, 6, 7} {
+		if v, ok := dict.At(k).Val(); !ok || v != k*10 {
+			t.Fatal("entry lost after reuse")
+		}
+	}
+}
+
+func TestPutStrict(t *testing.T) {
+	var dict = Of[string, int]()
+	dict.PutStrict("a", 1)
+	dict.PutStrict("b", 2)
+	if dict.Count() != 2 {
+		t.Fatal("dict count not eq 2")
+	}
+	if v, ok := dict.At("a").Val(); !ok || v != 1 {
+		t.Fatal("dict value not eq 1")
+	}
+	defer func() {
+		var r = recover()
+		if r == nil {
+			t.Fatal("duplicate key not panic")
+		}
+		if msg, ok := r.(string); !ok || msg != "duplicate key: a" {
+			t.Fatal("panic message not eq duplicate key: a")
+		}
+		if v, ok := dict.At("a").Val(); !ok || v != 1 {
+			t.Fatal("dict value was overwritten")
+		}
+	}()
+	dict.PutStrict("a", 3)
+}
+
+func TestMinMaxKey(t *testing.T) {
+	var dict1 = Of[int, string]()
+	if MaxKey(dict1).IsSome() || MinKey(dict1).IsSome() {
+		t.Fatal("empty dict key not none")
+	}
+	dict1.Add(3, "c")
+	dict1.Add(-7, "a")
+	dict1.Add(12, "d")
+	dict1.Add(-2, "b")
+	if v, ok := MaxKey(dict1).Val(); !ok || v != 12 {
+		t.Fatal("max key not eq 12")
+	}
+	if v, ok := MinKey(dict1).Val(); !ok || v != -7 {
+		t.Fatal("min key not eq -7")
+	}
+	var dict2 = Of(Entry[float64, int]{-1.5, 1}, Entry[float64, int]{-0.5, 2})
+	if v, ok := MaxKey(dict2).Val(); !ok || v != -0.5 {
+		t.Fatal("max key not eq -0.5")
+	}
+	if v, ok := MinKey(dict2).Val(); !ok || v != -1.5 {
+		t.Fatal("min key not eq -1.5")
+	}
+}
+
+func TestForEachPair(t *testing.T) {
+	var dict = Of(Entry[int, int]{1, 10}, Entry[int, int]{2, 20}, Entry[int, int]{3, 30})
+	var copies = make([]Entry[int, int], 0)
+	var pointers = make(map[*Entry[int, int]]struct{})
+	dict.ForEachPair(func(e *Entry[int, int]) {
+		copies = append(copies, *e)
+		pointers[e] = struct{}{}
+	})
+	if len(copies) != 3 {
+		t.Fatal("pair count not eq 3")
+	}
+	if len(pointers) != 1 {
+		t.Fatal("pair buffer not reused")
+	}
+	for _, e := range copies {
+		if v, ok := dict.At(e.Key).Val(); !ok || v != e.Value {
+			t.Fatal("pair value not eq dict value")
+		}
+	}
+}
+
+func TestForEachPairAllocs(t *testing.T) {
+	var dict = Make[int, int](1000)
+	for i := 0; i < 1000; i++ {
+		dict.Add(i, i)
+	}
+	var sum int
+	var action = func(e *Entry[int, int]) {
+		sum += e.Value
+	}
+	if allocs := testing.AllocsPerRun(100, func() {
+		dict.ForEachPair(action)
+	}); allocs != 0 {
+		t.Fatalf("allocs not eq 0: %v", allocs)
+	}
+}
+
+func BenchmarkForEachPair(b *testing.B) {
+	var dict = Make[int, int](1000)
+	for i := 0; i < 1000; i++ {
+		dict.Add(i, i)
+	}
+	var sum int
+	var action = func(e *Entry[int, int]) {
+		sum += e.Value
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dict.ForEachPair(action)
+	}
+}
+
+func TestInvalidateMatching(t *testing.T) {
+	var dict = Make[int, string](0)
+	for i := 0; i < 40; i++ {
+		dict.Add(i, fmt.Sprintf("%d", i))
+	}
+	var removed = dict.InvalidateMatching(func(k int) bool {
+		return k%3 == 0
+	})
+	if len(removed) != 14 {
+		t.Fatal("removed count not eq 14")
+	}
+	for _, k := range removed {
+		if k%3 != 0 {
+			t.Fatal("removed key not match")
+		}
+		if dict.Contains(k) {
+			t.Fatal("removed key still exists")
+		}
+	}
+	if dict.Count() != 26 {
+		t.Fatal("dict count not eq 26")
+	}
+	for i := 0; i < 40; i++ {
+		if i%3 != 0 {
+			if v, ok := dict.At(i).Val(); !ok || v != fmt.Sprintf("%d", i) {
+				t.Fatal("non-matching entry not survive")
+			}
+		}
+	}
+	if len(dict.InvalidateMatching(func(k int) bool { return k < 0 })) != 0 {
+		t.Fatal("removed count not eq 0")
+	}
+}
+
+func TestIsWellBalanced(t *testing.T) {
+	var good = MakeWithHasher[int, int](func(k int) uint64 {
+		return uint64(k)
+	}, 0)
+	var bad = MakeWithHasher[int, int](func(k int) uint64 {
+		return 0
+	}, 0)
+	if !good.IsWellBalanced(4) || !bad.IsWellBalanced(4) {
+		t.Fatal("empty dict not balanced")
+	}
+	for i := 0; i < 5; i++ {
+		good.Add(i, i)
+		if !good.IsWellBalanced(4) || !good.IsWellBalanced(2) {
+			t.Fatal("sparse dict with good hasher not balanced")
+		}
+	}
+	for i := 0; i < 100; i++ {
+		good.Add(i, i)
+		bad.Add(i, i)
+	}
+	if !good.IsWellBalanced(4) {
+		t.Fatal("good hasher not balanced")
+	}
+	if bad.IsWellBalanced(4) {
+		t.Fatal("pathological hasher balanced")
+	}
+}
+
+func TestCollectByType(t *testing.T) {
+	var dict = Of[string, any](
+		Entry[string, any]{"port", 8080},
+		Entry[string, any]{"retries", 3},
+		Entry[string, any]{"host", "localhost"},
+		Entry[string, any]{"debug", true},
+	)
+	var ints = CollectByType[string, int](dict)
+	if ints.Count() != 2 || ints.At("port").Get() != 8080 || ints.At("retries").Get() != 3 {
+		t.Fatal("int entries not eq")
+	}
+	var strs = CollectByType[string, string](dict)
+	if strs.Count() != 1 || strs.At("host").Get() != "localhost" {
+		t.Fatal("string entries not eq")
+	}
+	var bools = CollectByType[string, bool](dict)
+	if bools.Count() != 1 || !bools.At("debug").Get() {
+		t.Fatal("bool entries not eq")
+	}
+	var floats = CollectByType[string, float64](dict)
+	if floats.Count() != 0 {
+		t.Fatal("float entries not empty")
+	}
+}
+
+func TestDeepClone(t *testing.T) {
+	var dict1 = Of(Entry[string, []int]{"a", []int{1, 2}}, Entry[string, []int]{"b", []int{3}})
+	var dict2 = dict1.DeepClone(func(v []int) []int {
+		var r = make([]int, len(v))
+		copy(r, v)
+		return r
+	})
+	if dict2.Count() != 2 {
+		t.Fatal("clone count not eq 2")
+	}
+	dict2.At("a").Get()[0] = 100
+	dict2.At("b").Set(append(dict2.At("b").Get(), 4))
+	if dict1.At("a").Get()[0] != 1 {
+		t.Fatal("original value changed")
+	}
+	if len(dict1.At("b").Get()) != 1 {
+		t.Fatal("original value changed")
+	}
+	if dict2.At("a").Get()[0] != 100 || len(dict2.At("b").Get()) != 2 {
+		t.Fatal("clone value not changed")
+	}
+}
+
+func TestEqualKeys(t *testing.T) {
+	var dict1 = Of(Entry[string, int]{"a", 1}, Entry[string, int]{"b", 2})
+	var dict2 = Of(Entry[string, bool]{"b", true}, Entry[string, bool]{"a", false})
+	if !EqualKeys(dict1, dict2) {
+		t.Fatal("same keys not eq")
+	}
+	var dict3 = Of(Entry[string, bool]{"a", true}, Entry[string, bool]{"c", false})
+	if EqualKeys(dict1, dict3) {
+		t.Fatal("different keys eq")
+	}
+	var dict4 = Of(Entry[string, int]{"a", 1})
+	if EqualKeys(dict1, dict4) || EqualKeys(dict4, dict1) {
+		t.Fatal("different sizes eq")
+	}
+}
+
+func TestForEachBucket(t *testing.T) {
+	var hasher = func(k int) uint64 {
+		return uint64(k * 7)
+	}
+	var dict = MakeWithHasher[int, int](hasher, 0)
+	for i := 0; i < 50; i++ {
+		dict.Add(i, i*10)
+	}
+	dict.Remove(10)
+	var seen = make(map[int]int)
+	var buckets = 0
+	dict.ForEachBucket(func(bucketIndex int, entries []Entry[int, int]) {
+		buckets++
+		for _, e := range entries {
+			seen[e.Key]++
+			if int(hasher(e.Key)%uint64(len(dict.buckets))) != bucketIndex {
+				t.Fatal("entry in wrong bucket")
+			}
+			if e.Value != e.Key*10 {
+				t.Fatal("entry value not eq")
+			}
+		}
+		if len(entries) > 0 {
+			entries[0].Value = -1
+		}
+	})
+	if buckets != len(dict.buckets) {
+		t.Fatal("not every bucket visited")
+	}
+	if len(seen) != dict.Count() {
+		t.Fatal("not every entry visited")
+	}
+	for k, n := range seen {
+		if n != 1 || k == 10 {
+			t.Fatal("entry not in exactly one bucket")
+		}
+	}
+	for i := 0; i < 50; i++ {
+		if i != 10 && dict.At(i).Get() != i*10 {
+			t.Fatal("dict entry mutated")
+		}
+	}
+}
@@ -273,3 +273,35 @@ func (a *zipIterator[T, U]) Next() option.Option[Pair[T, U]] {
 	}
 	return option.None[Pair[T, U]]()
 }
+
+// Returns an Iterator yielding overlapping windows of size consecutive elements, advancing by one each step.
+// Nothing is yielded if the source has fewer than size elements, and each window is a fresh slice.
+// It panics if size is not positive.
+func SlidingWindow[T any](size int, source Iterator[T]) Iterator[[]T] {
+	if size <= 0 {
+		panic("window size must be positive")
+	}
+	return &slidingWindowIterator[T]{size, make([]T, 0), source}
+}
+
+type slidingWindowIterator[T any] struct {
+	size     int
+	window   []T
+	iterator Iterator[T]
+}
+
+func (a *slidingWindowIterator[T]) Next() option.Option[[]T] {
+	if len(a.window) == a.size {
+		a.window = a.window[1:]
+	}
+	for len(a.window) < a.size {
+		if v, ok := a.iterator.Next().Val(); ok {
+			a.window = append(a.window, v)
+		} else {
+			return option.None[[]T]()
+		}
+	}
+	var result = make([]T, a.size)
+	copy(result, a.window)
+	return option.Some(result)
+}
@@ -1,6 +1,7 @@
 package seq
 
 import (
+	"math"
 	"testing"
 )
 
@@ -16,3 +17,32 @@ func TestTransform(t *testing.T) {
 	}
 	ForEach(show, Map(square, Filter[int](even, Slice[int]([]int{1, 2, 3, 4, 5, 6, 7}))))
 }
+
+func TestSlidingWindow(t *testing.T) {
+	var windows = CollectToSlice(SlidingWindow(3, Slice[int]([]int{1, 2, 3, 4, 5}).Iterator()))
+	var expected = [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}
+	if len(windows) != len(expected) {
+		t.Fatal("SlidingWindow count error")
+	}
+	for i, w := range windows {
+		if !Equals[int](Slice[int](w), Slice[int](expected[i])) {
+			t.Fatal("SlidingWindow window error")
+		}
+	}
+	windows[0][0] = 100
+	if windows[1][0] != 2 {
+		t.Fatal("SlidingWindow window shared")
+	}
+	if SlidingWindow(3, Slice[int]([]int{1, 2}).Iterator()).Next().IsSome() {
+		t.Fatal("SlidingWindow short source error")
+	}
+	if SlidingWindow(math.MaxInt, Slice[int]([]int{1, 2}).Iterator()).Next().IsSome() {
+		t.Fatal("SlidingWindow huge size error")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("SlidingWindow non-positive size not panic")
+		}
+	}()
+	SlidingWindow(0, Slice[int]([]int{1, 2}).Iterator())
+}
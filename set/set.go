@@ -1,9 +1,15 @@
 package set
 
 import (
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
 	"github.com/kulics/gollection/dict"
 	"github.com/kulics/gollection/option"
 	"github.com/kulics/gollection/seq"
+	"golang.org/x/exp/constraints"
 )
 
 func Of[T comparable](elements ...T) *Set[T] {
@@ -23,6 +29,15 @@ func MakeWithHasher[T comparable](hasher func(data T) uint64, capacity int) *Set
 	return (*Set[T])(dict.MakeWithHasher[T, void](hasher, capacity))
 }
 
+// Build a new Set by converting each element of src, equal results are deduplicated and src is untouched.
+func ConvertSet[A, B comparable](src *Set[A], hasher func(B) uint64, convert func(A) B) *Set[B] {
+	var set = MakeWithHasher(hasher, src.Count())
+	seq.ForEach[A](func(a A) {
+		set.Add(convert(a))
+	}, src)
+	return set
+}
+
 func From[T comparable](collection seq.Collection[T]) *Set[T] {
 	var length = collection.Count()
 	var set = Make[T](length)
@@ -76,6 +91,74 @@ func (a *Set[T]) Clone() *Set[T] {
 	return (*Set[T])((*dict.Dict[T, void])(a).Clone())
 }
 
+// Returns a stable string representation of the elements sorted by less,
+// equal sets always produce the same string and it can be used as a map key.
+// Each formatted element is quoted, so format only needs to be injective,
+// and less must be a strict total order, otherwise equal sets may produce different strings.
+func (a *Set[T]) Canonical(less func(T, T) bool, format func(T) string) string {
+	var elements = seq.ToSlice[T](a)
+	sort.Slice(elements, func(i, j int) bool {
+		return less(elements[i], elements[j])
+	})
+	var builder strings.Builder
+	builder.WriteString("{")
+	for i, v := range elements {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(strconv.Quote(format(v)))
+	}
+	builder.WriteString("}")
+	return builder.String()
+}
+
+// Returns the elements in a uniformly random order determined by r.
+func (a *Set[T]) ShuffledSlice(r *rand.Rand) []T {
+	var elements = seq.ToSlice[T](a)
+	for i := len(elements) - 1; i > 0; i-- {
+		var j = r.Intn(i + 1)
+		elements[i], elements[j] = elements[j], elements[i]
+	}
+	return elements
+}
+
+// Returns an Iterator yielding the elements in slices of at most size, every element appears exactly once.
+// It panics if size is not positive.
+func (a *Set[T]) Batches(size int) seq.Iterator[[]T] {
+	if size <= 0 {
+		panic("batch size must be positive")
+	}
+	// The batch can never hold more than the whole set, so a huge size does not over-allocate.
+	var capacity = size
+	if count := a.Count(); count < capacity {
+		capacity = count
+	}
+	return &batchesIterator[T]{size, capacity, a.Iterator()}
+}
+
+type batchesIterator[T comparable] struct {
+	size     int
+	capacity int
+	it       seq.Iterator[T]
+}
+
+func (a *batchesIterator[T]) Next() option.Option[[]T] {
+	var first, ok = a.it.Next().Val()
+	if !ok {
+		return option.None[[]T]()
+	}
+	var batch = make([]T, 0, a.capacity)
+	batch = append(batch, first)
+	for len(batch) < a.size {
+		if v, ok := a.it.Next().Val(); ok {
+			batch = append(batch, v)
+		} else {
+			break
+		}
+	}
+	return option.Some(batch)
+}
+
 type hashSetIterator[T comparable] struct {
 	it seq.Iterator[dict.Entry[T, void]]
 }
@@ -87,6 +170,16 @@ func (a *hashSetIterator[T]) Next() option.Option[T] {
 	return option.None[T]()
 }
 
+// Returns the sum of all the elements in the Set, or 0 if it is empty.
+func SumSet[T constraints.Integer | constraints.Float](a *Set[T]) T {
+	return seq.Sum[T](a)
+}
+
+// Returns the product of all the elements in the Set, or 1 if it is empty.
+func ProductSet[T constraints.Integer | constraints.Float](a *Set[T]) T {
+	return seq.Product[T](a)
+}
+
 func Collector[T comparable]() seq.Collector[*Set[T], T, *Set[T]] {
 	return collector[T]{}
 }
@@ -1,7 +1,164 @@
 package set
 
-import "testing"
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/kulics/gollection/seq"
+)
 
 func TestHashSet(t *testing.T) {
 	var _ = Of[int]()
 }
+
+func TestCanonical(t *testing.T) {
+	var less = func(a, b int) bool {
+		return a < b
+	}
+	var format = func(a int) string {
+		return strconv.Itoa(a)
+	}
+	var set1 = Of(3, 1, 2)
+	var set2 = Of(2, 3, 1)
+	if set1.Canonical(less, format) != set2.Canonical(less, format) {
+		t.Fatal("equal sets canonical not eq")
+	}
+	if set1.Canonical(less, format) != `{"1", "2", "3"}` {
+		t.Fatal(`canonical not eq {"1", "2", "3"}`)
+	}
+	var set3 = Of(1, 2, 4)
+	if set1.Canonical(less, format) == set3.Canonical(less, format) {
+		t.Fatal("different sets canonical eq")
+	}
+	if Of[int]().Canonical(less, format) != "{}" {
+		t.Fatal("empty set canonical not eq {}")
+	}
+	var lessString = func(a, b string) bool {
+		return a < b
+	}
+	var formatString = func(a string) string {
+		return a
+	}
+	if Of("a, b").Canonical(lessString, formatString) == Of("a", "b").Canonical(lessString, formatString) {
+		t.Fatal("separator in element canonical eq")
+	}
+}
+
+func TestSumProductSet(t *testing.T) {
+	var set1 = Of(1, 2, 3, 4)
+	if SumSet(set1) != 10 {
+		t.Fatal("sum not eq 10")
+	}
+	if ProductSet(set1) != 24 {
+		t.Fatal("product not eq 24")
+	}
+	var set2 = Of(0.5, 4.0)
+	if SumSet(set2) != 4.5 {
+		t.Fatal("sum not eq 4.5")
+	}
+	if ProductSet(set2) != 2.0 {
+		t.Fatal("product not eq 2.0")
+	}
+	var set3 = Of[int]()
+	if SumSet(set3) != 0 {
+		t.Fatal("empty sum not eq 0")
+	}
+	if ProductSet(set3) != 1 {
+		t.Fatal("empty product not eq 1")
+	}
+}
+
+func TestConvertSet(t *testing.T) {
+	var hasher = func(s string) uint64 {
+		var h uint64
+		for _, c := range s {
+			h = h*31 + uint64(c)
+		}
+		return h
+	}
+	var src = Of(1, 2, 3)
+	var dst = ConvertSet(src, hasher, strconv.Itoa)
+	if dst.Count() != 3 || !dst.Contains("1") || !dst.Contains("2") || !dst.Contains("3") {
+		t.Fatal("converted set not eq {1, 2, 3}")
+	}
+	var parity = ConvertSet(Of(1, 2, 3, 4, 5), hasher, func(i int) string {
+		if i%2 == 0 {
+			return "even"
+		}
+		return "odd"
+	})
+	if parity.Count() != 2 || !parity.Contains("even") || !parity.Contains("odd") {
+		t.Fatal("converted set not deduplicated")
+	}
+	if src.Count() != 3 || !src.Contains(1) || !src.Contains(2) || !src.Contains(3) {
+		t.Fatal("source set changed")
+	}
+}
+
+func TestBatches(t *testing.T) {
+	var set = Make[int](0)
+	for i := 0; i < 10; i++ {
+		set.Add(i)
+	}
+	var batches = seq.CollectToSlice(set.Batches(3))
+	if len(batches) != 4 {
+		t.Fatal("batch count not eq 4")
+	}
+	var seen = Make[int](0)
+	var total = 0
+	for i, batch := range batches {
+		if i < 3 && len(batch) != 3 {
+			t.Fatal("batch size not eq 3")
+		}
+		for _, v := range batch {
+			seen.Add(v)
+			total++
+		}
+	}
+	if len(batches[3]) != 1 {
+		t.Fatal("last batch size not eq 1")
+	}
+	if total != 10 || seen.Count() != 10 || !seen.ContainsAll(seq.Slice[int]{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Fatal("batches not cover all elements")
+	}
+	if set.Batches(3).Next().IsNone() {
+		t.Fatal("new batches iterator is empty")
+	}
+	var whole = seq.CollectToSlice(set.Batches(100))
+	if len(whole) != 1 || len(whole[0]) != 10 {
+		t.Fatal("oversized batch not eq one batch of 10")
+	}
+	if v, ok := Of(1, 2, 3).Batches(math.MaxInt).Next().Val(); !ok || len(v) != 3 {
+		t.Fatal("max size batch not eq one batch of 3")
+	}
+	if Of[int]().Batches(3).Next().IsSome() {
+		t.Fatal("empty set batches not none")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("non-positive size not panic")
+		}
+	}()
+	set.Batches(0)
+}
+
+func TestShuffledSlice(t *testing.T) {
+	var set = Make[int](0)
+	for i := 0; i < 20; i++ {
+		set.Add(i)
+	}
+	var s1 = set.ShuffledSlice(rand.New(rand.NewSource(1)))
+	var s2 = set.ShuffledSlice(rand.New(rand.NewSource(2)))
+	if len(s1) != 20 || !set.ContainsAll(seq.Slice[int](s1)) || From[int](seq.Slice[int](s1)).Count() != 20 {
+		t.Fatal("shuffled slice not contains all elements")
+	}
+	if seq.Equals[int](seq.Slice[int](s1), seq.Slice[int](s2)) {
+		t.Fatal("different seeds produce same order")
+	}
+	var s3 = set.ShuffledSlice(rand.New(rand.NewSource(1)))
+	if !seq.Equals[int](seq.Slice[int](s1), seq.Slice[int](s3)) {
+		t.Fatal("same seed produce different order")
+	}
+}